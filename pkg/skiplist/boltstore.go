@@ -0,0 +1,234 @@
+package skiplist
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	nodesBucket = []byte("nodes")
+	metaBucket  = []byte("meta")
+	headMetaKey = []byte("head")
+	nextRefKey  = []byte("next_ref")
+)
+
+// KeyCodec encodes and decodes a SkipList key for on-disk storage.
+type KeyCodec[K any] interface {
+	EncodeKey(key K) ([]byte, error)
+	DecodeKey(data []byte) (K, error)
+}
+
+// ValueCodec encodes and decodes a SkipList value for on-disk storage.
+type ValueCodec[V any] interface {
+	EncodeValue(value V) ([]byte, error)
+	DecodeValue(data []byte) (V, error)
+}
+
+// BoltStore is a file-backed Store implementation on top of BoltDB. Each node is serialized as
+// (key, value, tower) using the caller-supplied codecs; NodeRefs in the tower are stored as
+// plain big-endian uint64s.
+type BoltStore[K any, V any] struct {
+	db         *bbolt.DB
+	keyCodec   KeyCodec[K]
+	valueCodec ValueCodec[V]
+}
+
+// OpenBoltStore opens (creating if necessary) a BoltDB file at path and returns it as a
+// Store[K, V]. Call Close when done with it.
+func OpenBoltStore[K any, V any](path string, keyCodec KeyCodec[K], valueCodec ValueCodec[V]) (*BoltStore[K, V], error) {
+	db, err := bbolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(nodesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(metaBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &BoltStore[K, V]{db: db, keyCodec: keyCodec, valueCodec: valueCodec}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (s *BoltStore[K, V]) Close() error {
+	return s.db.Close()
+}
+
+// Flush fsyncs the BoltDB file. Every PutNode/FreeRef/SaveHead/AllocRef already runs in its own
+// BoltDB transaction, which the database (opened with default options) commits and fsyncs before
+// returning, so writes are durable before Flush is called; batching Inserts/Deletes before a
+// Flush does not reduce the number of fsyncs.
+func (s *BoltStore[K, V]) Flush() error {
+	return s.db.Sync()
+}
+
+func (s *BoltStore[K, V]) GetNode(ref NodeRef) (*PersistentNode[K, V], error) {
+	var node *PersistentNode[K, V]
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		data := tx.Bucket(nodesBucket).Get(refBytes(ref))
+		if data == nil {
+			return fmt.Errorf("skiplist: no node for ref %d", ref)
+		}
+		n, err := s.decodeNode(data)
+		if err != nil {
+			return err
+		}
+		node = n
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (s *BoltStore[K, V]) PutNode(ref NodeRef, n *PersistentNode[K, V]) error {
+	data, err := s.encodeNode(n)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(nodesBucket).Put(refBytes(ref), data)
+	})
+}
+
+// AllocRef hands out the next ref from a persisted counter. A failure to commit the counter
+// update is rare enough (and not worth complicating this interface's signature over) that it is
+// treated as fatal here, the same way SkipList's constructor panics on a bad argument.
+func (s *BoltStore[K, V]) AllocRef() NodeRef {
+	var ref NodeRef
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(metaBucket)
+		next := uint64(1)
+		if data := b.Get(nextRefKey); data != nil {
+			next = binary.BigEndian.Uint64(data) + 1
+		}
+		if err := b.Put(nextRefKey, uint64Bytes(next)); err != nil {
+			return err
+		}
+		ref = NodeRef(next)
+		return nil
+	})
+	if err != nil {
+		panic(fmt.Sprintf("skiplist: BoltStore.AllocRef: %v", err))
+	}
+	return ref
+}
+
+func (s *BoltStore[K, V]) FreeRef(ref NodeRef) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(nodesBucket).Delete(refBytes(ref))
+	})
+}
+
+func (s *BoltStore[K, V]) LoadHead() (NodeRef, error) {
+	var ref NodeRef
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		if data := tx.Bucket(metaBucket).Get(headMetaKey); data != nil {
+			ref = NodeRef(binary.BigEndian.Uint64(data))
+		}
+		return nil
+	})
+	return ref, err
+}
+
+func (s *BoltStore[K, V]) SaveHead(ref NodeRef) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(metaBucket).Put(headMetaKey, uint64Bytes(uint64(ref)))
+	})
+}
+
+func refBytes(ref NodeRef) []byte {
+	return uint64Bytes(uint64(ref))
+}
+
+func uint64Bytes(v uint64) []byte {
+	var b [8]byte
+	binary.BigEndian.PutUint64(b[:], v)
+	return b[:]
+}
+
+func (s *BoltStore[K, V]) encodeNode(n *PersistentNode[K, V]) ([]byte, error) {
+	keyBytes, err := s.keyCodec.EncodeKey(n.Key)
+	if err != nil {
+		return nil, err
+	}
+	valueBytes, err := s.valueCodec.EncodeValue(n.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, 0, 4+len(keyBytes)+4+len(valueBytes)+4+len(n.Tower)*8)
+	buf = appendUint32Prefixed(buf, keyBytes)
+	buf = appendUint32Prefixed(buf, valueBytes)
+
+	var towerLen [4]byte
+	binary.BigEndian.PutUint32(towerLen[:], uint32(len(n.Tower)))
+	buf = append(buf, towerLen[:]...)
+	for _, ref := range n.Tower {
+		buf = append(buf, uint64Bytes(uint64(ref))...)
+	}
+	return buf, nil
+}
+
+func (s *BoltStore[K, V]) decodeNode(data []byte) (*PersistentNode[K, V], error) {
+	keyBytes, rest, err := readUint32Prefixed(data)
+	if err != nil {
+		return nil, err
+	}
+	valueBytes, rest, err := readUint32Prefixed(rest)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < 4 {
+		return nil, errors.New("skiplist: corrupt node: missing tower length")
+	}
+	towerLen := binary.BigEndian.Uint32(rest[:4])
+	rest = rest[4:]
+	if uint32(len(rest)) != towerLen*8 {
+		return nil, errors.New("skiplist: corrupt node: tower length mismatch")
+	}
+
+	key, err := s.keyCodec.DecodeKey(keyBytes)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.valueCodec.DecodeValue(valueBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	tower := make([]NodeRef, towerLen)
+	for i := range tower {
+		tower[i] = NodeRef(binary.BigEndian.Uint64(rest[i*8 : i*8+8]))
+	}
+
+	return &PersistentNode[K, V]{Key: key, Value: value, Tower: tower}, nil
+}
+
+func appendUint32Prefixed(buf, data []byte) []byte {
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(data)))
+	buf = append(buf, length[:]...)
+	return append(buf, data...)
+}
+
+func readUint32Prefixed(data []byte) (field, rest []byte, err error) {
+	if len(data) < 4 {
+		return nil, nil, errors.New("skiplist: corrupt node: truncated length prefix")
+	}
+	length := binary.BigEndian.Uint32(data[:4])
+	data = data[4:]
+	if uint32(len(data)) < length {
+		return nil, nil, errors.New("skiplist: corrupt node: truncated field")
+	}
+	return data[:length], data[length:], nil
+}
@@ -0,0 +1,291 @@
+package skiplist
+
+import (
+	"errors"
+	randv2 "math/rand/v2"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ErrArenaFull is returned by ConcurrentSkipList.Insert when the arena does not have enough
+// room left for another node. Callers implementing an LSM-style memtable should treat this as
+// a signal to flush the list and rotate in a fresh one.
+var ErrArenaFull = errors.New("skiplist: arena is full")
+
+// conMaxHeight bounds the tower height of a ConcurrentSkipList. Unlike SkipList, towers are
+// fixed-size arrays rather than slices sized to performantCapacity: nodes are handed out from
+// an arena and must not be reallocated once published, so their layout can't grow after the
+// fact.
+const conMaxHeight = 32
+
+// concurrentNode is allocated out of an arena and never moved once published into the list. A
+// reader only ever reaches one through a tower offset that was CAS'd in after the node's key and
+// value were set, so the memory model's happens-before on that CAS is what guarantees the reader
+// sees a fully-formed node. value is itself an atomic.Pointer rather than a plain V, since unlike
+// key (written once, before publish) it can be overwritten by a later Insert while other
+// goroutines are concurrently Find-ing or resurrecting the same node.
+type concurrentNode[K any, V any] struct {
+	key       K
+	value     atomic.Pointer[V]
+	tombstone atomic.Bool
+	tower     [conMaxHeight]atomic.Uint32
+}
+
+// arena is a lock-free bump allocator for concurrentNode[K, V] values, addressed by index rather
+// than pointer so that a tower slot can be an atomic.Uint32 instead of an atomic.Pointer. This
+// keeps node storage as one pre-sized, append-only slice rather than one GC allocation per node.
+// Offset 0 is reserved to mean "no next node"; the list's own head lives outside the arena.
+type arena[K any, V any] struct {
+	nodeSize uint32
+	total    uint32
+	nodes    []concurrentNode[K, V]
+	used     atomic.Uint32
+}
+
+func newArena[K any, V any](arenaBytes int) *arena[K, V] {
+	nodeSize := uint32(unsafe.Sizeof(concurrentNode[K, V]{}))
+	capacity := arenaBytes / int(nodeSize)
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &arena[K, V]{
+		nodeSize: nodeSize,
+		total:    uint32(capacity+1) * nodeSize,
+		// nodes[0] is never handed out; it only exists so offset 0 can mean "nil".
+		nodes: make([]concurrentNode[K, V], capacity+1),
+	}
+}
+
+func (a *arena[K, V]) allocate() (uint32, *concurrentNode[K, V], error) {
+	for {
+		used := a.used.Load()
+		offset := used + 1
+		if int(offset) >= len(a.nodes) {
+			return 0, nil, ErrArenaFull
+		}
+		if a.used.CompareAndSwap(used, offset) {
+			return offset, &a.nodes[offset], nil
+		}
+	}
+}
+
+func (a *arena[K, V]) at(offset uint32) *concurrentNode[K, V] {
+	if offset == 0 {
+		return nil
+	}
+	return &a.nodes[offset]
+}
+
+// ArenaStats reports how much of a ConcurrentSkipList's backing arena has been consumed.
+type ArenaStats struct {
+	UsedBytes  uint32
+	TotalBytes uint32
+}
+
+func (a *arena[K, V]) stats() ArenaStats {
+	return ArenaStats{UsedBytes: a.used.Load() * a.nodeSize, TotalBytes: a.total}
+}
+
+// ConcurrentSkipList is a lock-free skip list that supports any number of concurrent readers
+// together with one or more concurrent writers. Nodes are allocated out of a pre-sized arena
+// and addressed by index instead of pointer. Find is wait-free; Insert and Delete are lock-free
+// (Delete is a tombstone flip, so it can never block on a writer splicing in a new node).
+type ConcurrentSkipList[K any, V any] struct {
+	arena      *arena[K, V]
+	head       [conMaxHeight]atomic.Uint32
+	height     atomic.Int32
+	comparator func(a, b K) int
+}
+
+// NewConcurrentSkipList creates a ConcurrentSkipList backed by an arena of arenaBytes bytes.
+// Insert returns ErrArenaFull once that budget is exhausted.
+func NewConcurrentSkipList[K any, V any](keyComparator func(a, b K) int, arenaBytes int) *ConcurrentSkipList[K, V] {
+	if arenaBytes < 1 {
+		panic("arenaBytes must be at least 1")
+	}
+	s := &ConcurrentSkipList[K, V]{
+		arena:      newArena[K, V](arenaBytes),
+		comparator: keyComparator,
+	}
+	s.height.Store(1)
+	return s
+}
+
+// ArenaStats reports how much of the list's backing arena has been consumed.
+func (s *ConcurrentSkipList[K, V]) ArenaStats() ArenaStats {
+	return s.arena.stats()
+}
+
+func (s *ConcurrentSkipList[K, V]) randomHeight() int {
+	height := 1
+	for height < conMaxHeight && randv2.Uint32()&1 == 0 {
+		height++
+	}
+	return height
+}
+
+func (s *ConcurrentSkipList[K, V]) nextOffset(predOffset uint32, level int) uint32 {
+	if predOffset == 0 {
+		return s.head[level].Load()
+	}
+	return s.arena.at(predOffset).tower[level].Load()
+}
+
+func (s *ConcurrentSkipList[K, V]) casNext(predOffset uint32, level int, old, new uint32) bool {
+	if predOffset == 0 {
+		return s.head[level].CompareAndSwap(old, new)
+	}
+	return s.arena.at(predOffset).tower[level].CompareAndSwap(old, new)
+}
+
+// locate fills preds[level]/succs[level], for every level below the list's current height, with
+// the offset of the rightmost node with a key smaller than key and its immediate successor (0
+// meaning the head or "no node" respectively). It returns the node holding key, if present,
+// tombstoned or not. locate only ever reads towers, so it never blocks on a concurrent writer.
+func (s *ConcurrentSkipList[K, V]) locate(key K, preds, succs *[conMaxHeight]uint32) *concurrentNode[K, V] {
+	var found *concurrentNode[K, V]
+	pred := uint32(0)
+	for level := int(s.height.Load()) - 1; level >= 0; level-- {
+		next := s.nextOffset(pred, level)
+		for next != 0 {
+			n := s.arena.at(next)
+			cmp := s.comparator(n.key, key)
+			if cmp >= 0 {
+				if cmp == 0 {
+					found = n
+				}
+				break
+			}
+			pred = next
+			next = s.nextOffset(pred, level)
+		}
+		preds[level] = pred
+		succs[level] = next
+	}
+	return found
+}
+
+// Find looks up key. It is wait-free: it only follows tower pointers and never spins on a CAS.
+func (s *ConcurrentSkipList[K, V]) Find(key K) (V, bool) {
+	var preds, succs [conMaxHeight]uint32
+	found := s.locate(key, &preds, &succs)
+	if found == nil || found.tombstone.Load() {
+		return *new(V), false
+	}
+	return *found.value.Load(), true
+}
+
+// Insert adds key/value, or overwrites the value if key is already present (resurrecting the
+// node in place if a concurrent Delete had tombstoned it). On a lost CAS race at some level
+// (another writer spliced in a node between the same predecessor and successor) it walks forward
+// from its last-known predecessor at that level rather than restarting the whole search from the
+// top of the tower.
+//
+// Note that Insert does not fully linearize brand-new keys: if two writers insert the same new
+// key at the same moment, both can pass the existence check before either publishes a node, and
+// both get spliced in as duplicates. Callers that need a hard uniqueness guarantee under
+// concurrent inserts of the same new key should serialize writers for that key, e.g. by
+// sharding on it.
+func (s *ConcurrentSkipList[K, V]) Insert(key K, value V) error {
+	var preds, succs [conMaxHeight]uint32
+	for found := s.locate(key, &preds, &succs); found != nil; found = s.locate(key, &preds, &succs) {
+		if !found.tombstone.Load() {
+			found.value.Store(&value)
+			return nil
+		}
+		// Store the value before resurrecting, so a concurrent Find can never observe a
+		// live (non-tombstoned) node still holding the stale pre-delete value.
+		found.value.Store(&value)
+		if found.tombstone.CompareAndSwap(true, false) {
+			return nil
+		}
+	}
+
+	height := s.randomHeight()
+	for {
+		cur := s.height.Load()
+		if int32(height) <= cur || s.height.CompareAndSwap(cur, int32(height)) {
+			break
+		}
+	}
+
+	offset, n, err := s.arena.allocate()
+	if err != nil {
+		return err
+	}
+	n.key = key
+	n.value.Store(&value)
+	for level := 0; level < height; level++ {
+		n.tower[level].Store(succs[level])
+	}
+
+	for level := 0; level < height; level++ {
+		for !s.casNext(preds[level], level, succs[level], offset) {
+			// Someone else spliced a node in between preds[level] and succs[level]; walk forward
+			// from preds[level] to the new splice point and retry just this level instead of
+			// restarting from the top.
+			pred, next := preds[level], s.nextOffset(preds[level], level)
+			for next != 0 && s.comparator(s.arena.at(next).key, key) < 0 {
+				pred = next
+				next = s.nextOffset(pred, level)
+			}
+			preds[level], succs[level] = pred, next
+			n.tower[level].Store(next)
+		}
+	}
+	return nil
+}
+
+// Delete logically removes key by flipping its tombstone bit, which keeps Delete lock-free and
+// never contends with a writer splicing a new node in. The node stays physically linked until
+// Compact runs.
+func (s *ConcurrentSkipList[K, V]) Delete(key K) (V, bool) {
+	var preds, succs [conMaxHeight]uint32
+	found := s.locate(key, &preds, &succs)
+	if found == nil {
+		return *new(V), false
+	}
+	if !found.tombstone.CompareAndSwap(false, true) {
+		return *new(V), false
+	}
+	return *found.value.Load(), true
+}
+
+// Compact physically unlinks tombstoned nodes at every level, reclaiming the work Delete
+// deferred. It is safe to run alongside readers and writers; concurrent Inserts and Deletes
+// just retry their CAS if Compact wins a race on the same predecessor.
+func (s *ConcurrentSkipList[K, V]) Compact() {
+	for level := int(s.height.Load()) - 1; level >= 0; level-- {
+		pred := uint32(0)
+		next := s.nextOffset(pred, level)
+		for next != 0 {
+			n := s.arena.at(next)
+			if n.tombstone.Load() {
+				after := n.tower[level].Load()
+				if s.casNext(pred, level, next, after) {
+					next = after
+					continue
+				}
+				next = s.nextOffset(pred, level)
+				continue
+			}
+			pred = next
+			next = s.nextOffset(pred, level)
+		}
+	}
+}
+
+// Keys returns every non-tombstoned key in ascending order. It takes a consistent snapshot of
+// level 0 only in the sense that any node it observes is fully formed; nodes inserted or
+// deleted concurrently with the scan may or may not be included.
+func (s *ConcurrentSkipList[K, V]) Keys() []K {
+	var keys []K
+	for offset := s.head[0].Load(); offset != 0; {
+		n := s.arena.at(offset)
+		if !n.tombstone.Load() {
+			keys = append(keys, n.key)
+		}
+		offset = n.tower[0].Load()
+	}
+	return keys
+}
@@ -0,0 +1,183 @@
+package skiplist
+
+import (
+	"cmp"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSkipList_InsertFindDelete(t *testing.T) {
+	s := NewConcurrentSkipList[int, string](cmp.Compare[int], 1<<16)
+
+	if _, found := s.Find(1); found {
+		t.Errorf("expected key 1 to be absent from an empty list")
+	}
+
+	if err := s.Insert(1, "one"); err != nil {
+		t.Fatalf("unexpected error inserting: %v", err)
+	}
+	if value, found := s.Find(1); !found || value != "one" {
+		t.Errorf("expected to find (1, \"one\"), got (%v, %v)", value, found)
+	}
+
+	// Re-inserting the same key overwrites the value.
+	if err := s.Insert(1, "uno"); err != nil {
+		t.Fatalf("unexpected error re-inserting: %v", err)
+	}
+	if value, found := s.Find(1); !found || value != "uno" {
+		t.Errorf("expected to find (1, \"uno\"), got (%v, %v)", value, found)
+	}
+
+	oldValue, deleted := s.Delete(1)
+	if !deleted || oldValue != "uno" {
+		t.Errorf("expected to delete (1, \"uno\"), got (%v, %v)", oldValue, deleted)
+	}
+	if _, found := s.Find(1); found {
+		t.Errorf("expected key 1 to be gone after Delete")
+	}
+
+	// Deleting an already-deleted key reports false.
+	if _, deleted := s.Delete(1); deleted {
+		t.Errorf("expected deleting an already-deleted key to report false")
+	}
+
+	// Inserting again after a delete resurrects the node rather than failing.
+	if err := s.Insert(1, "un"); err != nil {
+		t.Fatalf("unexpected error resurrecting: %v", err)
+	}
+	if value, found := s.Find(1); !found || value != "un" {
+		t.Errorf("expected to find (1, \"un\") after resurrection, got (%v, %v)", value, found)
+	}
+}
+
+func TestConcurrentSkipList_ArenaFull(t *testing.T) {
+	s := NewConcurrentSkipList[int, int](cmp.Compare[int], 1)
+
+	inserted := 0
+	for i := 0; i < 10000; i++ {
+		if err := s.Insert(i, i); err != nil {
+			if err != ErrArenaFull {
+				t.Fatalf("expected ErrArenaFull, got %v", err)
+			}
+			break
+		}
+		inserted++
+	}
+	if inserted == 0 {
+		t.Fatalf("expected at least one node to fit before the arena filled up")
+	}
+
+	stats := s.ArenaStats()
+	if stats.UsedBytes == 0 || stats.UsedBytes > stats.TotalBytes {
+		t.Errorf("expected 0 < UsedBytes <= TotalBytes, got used=%d total=%d", stats.UsedBytes, stats.TotalBytes)
+	}
+}
+
+// TestConcurrentSkipList_ConcurrentInsertAndDelete drives N goroutines inserting disjoint key
+// sets while all of them also overwrite one shared overlap key, and verifies that Keys() then
+// yields the sorted union. It then tombstones every non-overlap key concurrently and checks that
+// only the overlap key remains after Compact. Run with -race to exercise the lock-free splicing.
+func TestConcurrentSkipList_ConcurrentInsertAndDelete(t *testing.T) {
+	const goroutines = 8
+	const perGoroutine = 500
+	const overlapKey = -1
+
+	s := NewConcurrentSkipList[int, int](cmp.Compare[int], 1<<22)
+
+	// Seed the overlap key up front so every goroutine's later write to it is an overwrite, not
+	// a race to insert the same brand-new key (Insert only linearizes overwrites of an existing
+	// key, see the note on Insert).
+	if err := s.Insert(overlapKey, -1); err != nil {
+		t.Fatalf("unexpected error seeding overlap key: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				key := g*perGoroutine + i
+				if err := s.Insert(key, key); err != nil {
+					t.Errorf("goroutine %d: unexpected error inserting %d: %v", g, key, err)
+					return
+				}
+				if i%7 == 0 {
+					// Overlapping writes to a shared key from every goroutine.
+					s.Insert(overlapKey, g)
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	if _, found := s.Find(overlapKey); !found {
+		t.Errorf("expected the shared overlap key to be present")
+	}
+
+	keys := s.Keys()
+	if !sort.IntsAreSorted(keys) {
+		t.Fatalf("expected Keys() to be sorted, got %v", keys)
+	}
+
+	want := map[int]bool{overlapKey: true}
+	for g := 0; g < goroutines; g++ {
+		for i := 0; i < perGoroutine; i++ {
+			want[g*perGoroutine+i] = true
+		}
+	}
+	if len(keys) != len(want) {
+		t.Fatalf("expected %d keys, got %d", len(want), len(keys))
+	}
+	for _, k := range keys {
+		if !want[k] {
+			t.Errorf("unexpected key %d in union", k)
+		}
+	}
+
+	// Now tombstone every non-overlap key concurrently and confirm Compact leaves the list
+	// consistent.
+	wg.Add(goroutines)
+	for g := 0; g < goroutines; g++ {
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < perGoroutine; i++ {
+				s.Delete(g*perGoroutine + i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	s.Compact()
+
+	keys = s.Keys()
+	if len(keys) != 1 || keys[0] != overlapKey {
+		t.Fatalf("expected only the overlap key %d to remain after Compact, got %v", overlapKey, keys)
+	}
+}
+
+func TestConcurrentSkipList_KeysSortedUnion(t *testing.T) {
+	s := NewConcurrentSkipList[string, int](cmp.Compare[string], 1<<16)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Insert(fmt.Sprintf("g%d-%03d", g, i), i)
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	keys := s.Keys()
+	if !sort.StringsAreSorted(keys) {
+		t.Fatalf("expected Keys() to be sorted, got %v", keys)
+	}
+	if len(keys) != 200 {
+		t.Fatalf("expected 200 keys, got %d", len(keys))
+	}
+}
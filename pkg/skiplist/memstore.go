@@ -0,0 +1,72 @@
+package skiplist
+
+import (
+	"fmt"
+	"sync"
+)
+
+// MemStore is an in-memory Store, useful for tests and for exercising PersistentSkipList
+// without a real backing store. It is safe for concurrent use.
+type MemStore[K any, V any] struct {
+	mu      sync.Mutex
+	nodes   map[NodeRef]*PersistentNode[K, V]
+	nextRef NodeRef
+	headRef NodeRef
+}
+
+// NewMemStore creates an empty MemStore.
+func NewMemStore[K any, V any]() *MemStore[K, V] {
+	return &MemStore[K, V]{nodes: make(map[NodeRef]*PersistentNode[K, V])}
+}
+
+// GetNode returns a copy of the stored node, so that a caller mutating it in place (the way
+// PersistentSkipList does before calling PutNode) can't corrupt the store's state.
+func (m *MemStore[K, V]) GetNode(ref NodeRef) (*PersistentNode[K, V], error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n, ok := m.nodes[ref]
+	if !ok {
+		return nil, fmt.Errorf("skiplist: no node for ref %d", ref)
+	}
+	return cloneNode(n), nil
+}
+
+func (m *MemStore[K, V]) PutNode(ref NodeRef, n *PersistentNode[K, V]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes[ref] = cloneNode(n)
+	return nil
+}
+
+func (m *MemStore[K, V]) AllocRef() NodeRef {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nextRef++
+	return m.nextRef
+}
+
+func (m *MemStore[K, V]) FreeRef(ref NodeRef) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.nodes, ref)
+	return nil
+}
+
+func (m *MemStore[K, V]) LoadHead() (NodeRef, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.headRef, nil
+}
+
+func (m *MemStore[K, V]) SaveHead(ref NodeRef) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.headRef = ref
+	return nil
+}
+
+func cloneNode[K any, V any](n *PersistentNode[K, V]) *PersistentNode[K, V] {
+	cp := *n
+	cp.Tower = append([]NodeRef(nil), n.Tower...)
+	return &cp
+}
@@ -0,0 +1,395 @@
+package skiplist
+
+import (
+	"container/list"
+	"math"
+	randv2 "math/rand/v2"
+	"sync"
+)
+
+// NodeRef identifies a node inside a Store. The zero value means "no node", the same way a nil
+// *node does for the in-memory SkipList; a Store implementation must never hand out 0 from
+// AllocRef.
+type NodeRef uint64
+
+// PersistentNode is the on-disk (or off-heap) equivalent of node: a key, a value and a tower of
+// NodeRefs instead of *node pointers.
+type PersistentNode[K any, V any] struct {
+	Key   K
+	Value V
+	Tower []NodeRef
+}
+
+// Store is a pluggable backing store for PersistentSkipList, so the list's nodes can live
+// somewhere other than the Go heap (on disk, in a remote KV store, etc). Insert/Find/Delete on
+// PersistentSkipList surface whatever error a Store returns, since unlike SkipList this I/O can
+// fail.
+type Store[K any, V any] interface {
+	GetNode(ref NodeRef) (*PersistentNode[K, V], error)
+	PutNode(ref NodeRef, n *PersistentNode[K, V]) error
+	AllocRef() NodeRef
+	FreeRef(ref NodeRef) error
+	LoadHead() (NodeRef, error)
+	SaveHead(ref NodeRef) error
+}
+
+// PersistentSkipList is a sibling of SkipList backed by a Store rather than Go-heap nodes.
+// Its API mirrors SkipList's, except every method that touches the store can fail.
+type PersistentSkipList[K any, V any] struct {
+	store         Store[K, V]
+	comparator    func(a, b K) int
+	maxHeight     int
+	height        int
+	probabilities []uint32
+	headRef       NodeRef
+}
+
+// NewPersistentSkipList creates a PersistentSkipList on top of store. If store already holds a
+// head node (LoadHead returns a non-zero ref), that list is reopened; otherwise a fresh head
+// node is allocated and persisted. When reopening, performantCapacity and pValue must match
+// what the store was originally created with, since they determine the head node's tower size.
+func NewPersistentSkipList[K any, V any](keyComparator func(a, b K) int, store Store[K, V], performantCapacity int, pValue float64) (*PersistentSkipList[K, V], error) {
+	if performantCapacity < 1 {
+		panic("performantCapacity must be at least 1")
+	}
+	if pValue <= 0.0 || pValue >= 1.0 {
+		panic("pValue must be in the range (0, 1)")
+	}
+	maxHeight := int(math.Ceil(logBaseX(1.0/pValue, float64(performantCapacity))))
+
+	s := &PersistentSkipList[K, V]{
+		store:         store,
+		comparator:    keyComparator,
+		maxHeight:     maxHeight,
+		probabilities: make([]uint32, maxHeight),
+	}
+	levelProb := 1.0
+	for level := 0; level < maxHeight; level++ {
+		s.probabilities[level] = uint32(levelProb * float64(math.MaxUint32))
+		levelProb *= pValue
+	}
+
+	headRef, err := store.LoadHead()
+	if err != nil {
+		return nil, err
+	}
+	var head *PersistentNode[K, V]
+	if headRef == 0 {
+		headRef = store.AllocRef()
+		head = &PersistentNode[K, V]{Tower: make([]NodeRef, maxHeight)}
+		if err := store.PutNode(headRef, head); err != nil {
+			return nil, err
+		}
+		if err := store.SaveHead(headRef); err != nil {
+			return nil, err
+		}
+	} else {
+		head, err = store.GetNode(headRef)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.headRef = headRef
+
+	height := 1
+	for level := len(head.Tower) - 1; level >= 1; level-- {
+		if head.Tower[level] != 0 {
+			height = level + 1
+			break
+		}
+	}
+	s.height = height
+
+	return s, nil
+}
+
+func (s *PersistentSkipList[K, V]) randomHeight() int {
+	randVal := randv2.Uint32()
+	height := 1
+	for height < s.maxHeight && randVal <= s.probabilities[height] {
+		height++
+	}
+	return height
+}
+
+// search returns the node matching key (if any) along with its ref, and for every level the ref
+// of the rightmost node with a smaller key (s.headRef meaning the head). Levels at or above the
+// list's current height are left 0.
+func (s *PersistentSkipList[K, V]) search(key K) (*PersistentNode[K, V], NodeRef, []NodeRef, error) {
+	rightmostSmaller := make([]NodeRef, s.maxHeight)
+
+	currentRef := s.headRef
+	current, err := s.store.GetNode(currentRef)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var next *PersistentNode[K, V]
+	var nextRef NodeRef
+	for level := s.height - 1; level >= 0; level-- {
+		for {
+			nextRef = current.Tower[level]
+			if nextRef == 0 {
+				next = nil
+				break
+			}
+			next, err = s.store.GetNode(nextRef)
+			if err != nil {
+				return nil, 0, nil, err
+			}
+			if s.comparator(key, next.Key) <= 0 {
+				break
+			}
+			currentRef = nextRef
+			current = next
+		}
+		rightmostSmaller[level] = currentRef
+	}
+
+	if next != nil && s.comparator(key, next.Key) == 0 {
+		return next, nextRef, rightmostSmaller, nil
+	}
+	return nil, 0, rightmostSmaller, nil
+}
+
+// Insert inserts a new key-value pair. If the key already exists the old value is returned
+// along with true; if it did not, false is returned. An error is returned if the store fails.
+func (s *PersistentSkipList[K, V]) Insert(key K, value V) (V, bool, error) {
+	found, foundRef, rightmostSmaller, err := s.search(key)
+	if err != nil {
+		return *new(V), false, err
+	}
+	if found != nil {
+		oldValue := found.Value
+		found.Value = value
+		if err := s.store.PutNode(foundRef, found); err != nil {
+			return *new(V), false, err
+		}
+		return oldValue, true, nil
+	}
+
+	newNodeHeight := s.randomHeight()
+	if newNodeHeight > s.height {
+		for level := s.height; level < newNodeHeight; level++ {
+			rightmostSmaller[level] = s.headRef
+		}
+		s.height = newNodeHeight
+	}
+
+	newRef := s.store.AllocRef()
+	newNode := &PersistentNode[K, V]{Key: key, Value: value, Tower: make([]NodeRef, newNodeHeight)}
+
+	// Multiple levels commonly share the same predecessor (head, in particular, for every level
+	// the list just grew into), so predecessors are deduplicated here: each distinct ref is
+	// fetched once, has every one of its levels updated in memory, and is written back once.
+	predNodes := make(map[NodeRef]*PersistentNode[K, V], newNodeHeight)
+	predOrder := make([]NodeRef, 0, newNodeHeight)
+	for level := 0; level < newNodeHeight; level++ {
+		predRef := rightmostSmaller[level]
+		predNode, ok := predNodes[predRef]
+		if !ok {
+			var err error
+			predNode, err = s.store.GetNode(predRef)
+			if err != nil {
+				return *new(V), false, err
+			}
+			predNodes[predRef] = predNode
+			predOrder = append(predOrder, predRef)
+		}
+		newNode.Tower[level] = predNode.Tower[level]
+	}
+
+	// Write the new node before splicing any predecessor into it, so a crash (or Flush) in
+	// between leaves at worst an orphaned, harmless node rather than a predecessor pointing at a
+	// NodeRef nothing has written yet.
+	if err := s.store.PutNode(newRef, newNode); err != nil {
+		return *new(V), false, err
+	}
+
+	for level := 0; level < newNodeHeight; level++ {
+		predNodes[rightmostSmaller[level]].Tower[level] = newRef
+	}
+	for _, predRef := range predOrder {
+		if err := s.store.PutNode(predRef, predNodes[predRef]); err != nil {
+			return *new(V), false, err
+		}
+	}
+
+	return *new(V), false, nil
+}
+
+// Delete deletes a key-value pair. If the key was found the old value is returned along with
+// true; if it was not, false is returned. An error is returned if the store fails.
+func (s *PersistentSkipList[K, V]) Delete(key K) (V, bool, error) {
+	found, foundRef, rightmostSmaller, err := s.search(key)
+	if err != nil {
+		return *new(V), false, err
+	}
+	if found == nil {
+		return *new(V), false, nil
+	}
+
+	for level := 0; level < s.height; level++ {
+		predRef := rightmostSmaller[level]
+		predNode, err := s.store.GetNode(predRef)
+		if err != nil {
+			return *new(V), false, err
+		}
+		if predNode.Tower[level] != foundRef {
+			break
+		}
+		predNode.Tower[level] = found.Tower[level]
+		if err := s.store.PutNode(predRef, predNode); err != nil {
+			return *new(V), false, err
+		}
+	}
+
+	if err := s.store.FreeRef(foundRef); err != nil {
+		return *new(V), false, err
+	}
+
+	for s.height > 1 {
+		head, err := s.store.GetNode(s.headRef)
+		if err != nil {
+			return *new(V), false, err
+		}
+		if head.Tower[s.height-1] != 0 {
+			break
+		}
+		s.height--
+	}
+
+	return found.Value, true, nil
+}
+
+// Find finds a value in the list given its key. If the key is found the value is returned along
+// with true, otherwise false is returned. An error is returned if the store fails.
+func (s *PersistentSkipList[K, V]) Find(key K) (V, bool, error) {
+	found, _, _, err := s.search(key)
+	if err != nil {
+		return *new(V), false, err
+	}
+	if found != nil {
+		return found.Value, true, nil
+	}
+	return *new(V), false, nil
+}
+
+// Flush asks the backing store to persist any buffered writes. This is a no-op unless the
+// underlying Store opts in by implementing `Flush() error` itself. Whether writes wait for Flush
+// depends on the Store: MemStore keeps nothing on disk and has no Flush, and BoltStore commits
+// and fsyncs every write as it happens, so its Flush only issues one more fsync.
+func (s *PersistentSkipList[K, V]) Flush() error {
+	if f, ok := s.store.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// NodeCache is an in-process LRU cache in front of a Store, so that repeatedly-visited nodes
+// (head, and anything near the top of the tower) don't hit the backing store on every hop.
+// NodeCache itself implements Store, so it can be passed to NewPersistentSkipList directly.
+type NodeCache[K any, V any] struct {
+	mu       sync.Mutex
+	store    Store[K, V]
+	capacity int
+	ll       *list.List
+	items    map[NodeRef]*list.Element
+}
+
+type nodeCacheEntry[K any, V any] struct {
+	ref  NodeRef
+	node *PersistentNode[K, V]
+}
+
+// NewNodeCache wraps store with an LRU cache that holds up to capacity nodes.
+func NewNodeCache[K any, V any](store Store[K, V], capacity int) *NodeCache[K, V] {
+	if capacity < 1 {
+		panic("capacity must be at least 1")
+	}
+	return &NodeCache[K, V]{
+		store:    store,
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[NodeRef]*list.Element, capacity),
+	}
+}
+
+func (c *NodeCache[K, V]) GetNode(ref NodeRef) (*PersistentNode[K, V], error) {
+	c.mu.Lock()
+	if el, ok := c.items[ref]; ok {
+		c.ll.MoveToFront(el)
+		node := el.Value.(*nodeCacheEntry[K, V]).node
+		c.mu.Unlock()
+		return node, nil
+	}
+	c.mu.Unlock()
+
+	node, err := c.store.GetNode(ref)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.insertLocked(ref, node)
+	c.mu.Unlock()
+	return node, nil
+}
+
+func (c *NodeCache[K, V]) PutNode(ref NodeRef, n *PersistentNode[K, V]) error {
+	if err := c.store.PutNode(ref, n); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.insertLocked(ref, n)
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *NodeCache[K, V]) AllocRef() NodeRef {
+	return c.store.AllocRef()
+}
+
+func (c *NodeCache[K, V]) FreeRef(ref NodeRef) error {
+	err := c.store.FreeRef(ref)
+	c.mu.Lock()
+	if el, ok := c.items[ref]; ok {
+		c.ll.Remove(el)
+		delete(c.items, ref)
+	}
+	c.mu.Unlock()
+	return err
+}
+
+func (c *NodeCache[K, V]) LoadHead() (NodeRef, error) {
+	return c.store.LoadHead()
+}
+
+func (c *NodeCache[K, V]) SaveHead(ref NodeRef) error {
+	return c.store.SaveHead(ref)
+}
+
+// Flush delegates to the wrapped store if it supports it, so a NodeCache-wrapped store still
+// works with PersistentSkipList.Flush.
+func (c *NodeCache[K, V]) Flush() error {
+	if f, ok := c.store.(interface{ Flush() error }); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+func (c *NodeCache[K, V]) insertLocked(ref NodeRef, node *PersistentNode[K, V]) {
+	if el, ok := c.items[ref]; ok {
+		el.Value.(*nodeCacheEntry[K, V]).node = node
+		c.ll.MoveToFront(el)
+		return
+	}
+	el := c.ll.PushFront(&nodeCacheEntry[K, V]{ref: ref, node: node})
+	c.items[ref] = el
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*nodeCacheEntry[K, V]).ref)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package skiplist
+
+import (
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestPersistentSkipList_InsertFindDelete(t *testing.T) {
+	store := NewMemStore[int, string]()
+	s, err := NewPersistentSkipList[int, string](cmp.Compare[int], store, 64, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error creating list: %v", err)
+	}
+
+	key := 1
+	value := "value1"
+
+	oldValue, updated, err := s.Insert(key, value)
+	if err != nil {
+		t.Fatalf("unexpected error inserting: %v", err)
+	}
+	if updated {
+		t.Errorf("expected updated to be false, got true")
+	}
+	if oldValue != "" {
+		t.Errorf("expected oldValue to be empty, got %v", oldValue)
+	}
+
+	foundValue, found, err := s.Find(key)
+	if err != nil {
+		t.Fatalf("unexpected error finding: %v", err)
+	}
+	if !found || foundValue != value {
+		t.Errorf("expected to find (%v, %v), got (%v, %v)", key, value, foundValue, found)
+	}
+
+	newValue := "value2"
+	oldValue, updated, err = s.Insert(key, newValue)
+	if err != nil {
+		t.Fatalf("unexpected error re-inserting: %v", err)
+	}
+	if !updated || oldValue != value {
+		t.Errorf("expected updated to be true with oldValue %v, got updated=%v oldValue=%v", value, updated, oldValue)
+	}
+
+	oldValue, deleted, err := s.Delete(key)
+	if err != nil {
+		t.Fatalf("unexpected error deleting: %v", err)
+	}
+	if !deleted || oldValue != newValue {
+		t.Errorf("expected to delete (%v, %v), got (%v, %v)", key, newValue, oldValue, deleted)
+	}
+
+	if _, found, err := s.Find(key); err != nil || found {
+		t.Errorf("expected key to be gone, got found=%v err=%v", found, err)
+	}
+
+	if _, deleted, err := s.Delete(key); err != nil || deleted {
+		t.Errorf("expected deleting an already-deleted key to report false, got deleted=%v err=%v", deleted, err)
+	}
+}
+
+func TestPersistentSkipList_ManyKeysSurviveReopen(t *testing.T) {
+	store := NewMemStore[int, int]()
+	s, err := NewPersistentSkipList[int, int](cmp.Compare[int], store, 64, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error creating list: %v", err)
+	}
+
+	for i := 0; i < 200; i++ {
+		if _, _, err := s.Insert(i, i*i); err != nil {
+			t.Fatalf("unexpected error inserting %d: %v", i, err)
+		}
+	}
+
+	for i := 0; i < 200; i += 2 {
+		if _, _, err := s.Delete(i); err != nil {
+			t.Fatalf("unexpected error deleting %d: %v", i, err)
+		}
+	}
+
+	// Reopen on top of the same store, simulating a process restart.
+	reopened, err := NewPersistentSkipList[int, int](cmp.Compare[int], store, 64, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error reopening list: %v", err)
+	}
+
+	for i := 0; i < 200; i++ {
+		value, found, err := reopened.Find(i)
+		if err != nil {
+			t.Fatalf("unexpected error finding %d: %v", i, err)
+		}
+		if i%2 == 0 {
+			if found {
+				t.Errorf("expected key %d to be gone after reopen, got value %v", i, value)
+			}
+			continue
+		}
+		if !found || value != i*i {
+			t.Errorf("expected key %d to have value %d after reopen, got %v (found=%v)", i, i*i, value, found)
+		}
+	}
+}
+
+func TestNodeCache_HitsAndEvictsAndWraps(t *testing.T) {
+	store := NewMemStore[int, string]()
+	cache := NewNodeCache[int, string](store, 2)
+
+	refA := store.AllocRef()
+	if err := cache.PutNode(refA, &PersistentNode[int, string]{Key: 1, Value: "a"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	refB := store.AllocRef()
+	if err := cache.PutNode(refB, &PersistentNode[int, string]{Key: 2, Value: "b"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	refC := store.AllocRef()
+	if err := cache.PutNode(refC, &PersistentNode[int, string]{Key: 3, Value: "c"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// refA should have been evicted (capacity 2, inserted first).
+	if _, cached := cache.items[refA]; cached {
+		t.Errorf("expected refA to have been evicted from the cache")
+	}
+	n, err := cache.GetNode(refA)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n.Value != "a" {
+		t.Errorf("expected to still reach refA via the underlying store, got %v", n.Value)
+	}
+
+	// refC is still cached, so reading it through the cache is a hit even once the underlying
+	// store no longer has it.
+	if err := store.FreeRef(refC); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n, err := cache.GetNode(refC); err != nil || n.Value != "c" {
+		t.Errorf("expected a cache hit for refC, got (%v, %v)", n, err)
+	}
+
+	if err := cache.FreeRef(refB); err != nil {
+		t.Fatalf("unexpected error freeing refB: %v", err)
+	}
+	if _, err := cache.GetNode(refB); err == nil {
+		t.Errorf("expected an error reading a freed ref")
+	}
+}
+
+// intCodec and stringCodec are minimal KeyCodec/ValueCodec implementations used to exercise
+// BoltStore.
+type intCodec struct{}
+
+func (intCodec) EncodeKey(key int) ([]byte, error) {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(key))
+	return buf, nil
+}
+
+func (intCodec) DecodeKey(data []byte) (int, error) {
+	if len(data) != 8 {
+		return 0, errors.New("intCodec: wrong length")
+	}
+	return int(binary.BigEndian.Uint64(data)), nil
+}
+
+type stringCodec struct{}
+
+func (stringCodec) EncodeValue(value string) ([]byte, error) {
+	return []byte(value), nil
+}
+
+func (stringCodec) DecodeValue(data []byte) (string, error) {
+	return string(data), nil
+}
+
+func TestBoltStore_InsertFindDeleteAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skiplist.bolt")
+
+	store, err := OpenBoltStore[int, string](path, intCodec{}, stringCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error opening BoltStore: %v", err)
+	}
+
+	s, err := NewPersistentSkipList[int, string](cmp.Compare[int], store, 64, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error creating list: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		if _, _, err := s.Insert(i, fmt.Sprintf("value%d", i)); err != nil {
+			t.Fatalf("unexpected error inserting %d: %v", i, err)
+		}
+	}
+	if err := s.Flush(); err != nil {
+		t.Fatalf("unexpected error flushing: %v", err)
+	}
+	if err := store.Close(); err != nil {
+		t.Fatalf("unexpected error closing store: %v", err)
+	}
+
+	reopenedStore, err := OpenBoltStore[int, string](path, intCodec{}, stringCodec{})
+	if err != nil {
+		t.Fatalf("unexpected error reopening BoltStore: %v", err)
+	}
+	defer reopenedStore.Close()
+
+	reopened, err := NewPersistentSkipList[int, string](cmp.Compare[int], reopenedStore, 64, 0.5)
+	if err != nil {
+		t.Fatalf("unexpected error reopening list: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		value, found, err := reopened.Find(i)
+		if err != nil {
+			t.Fatalf("unexpected error finding %d: %v", i, err)
+		}
+		want := fmt.Sprintf("value%d", i)
+		if !found || value != want {
+			t.Errorf("expected key %d to have value %v after reopen, got %v (found=%v)", i, want, value, found)
+		}
+	}
+}
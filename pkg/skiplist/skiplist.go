@@ -9,13 +9,30 @@ type node[K any, V any] struct {
 	key   K
 	value V
 	tower []*node[K, V]
+	// height is how many levels of tower this node actually occupies. tower is always allocated
+	// at the list's maxHeight, so a nil tower entry doesn't tell you whether a node stops there
+	// or is just last at that level; Clone needs the real height to rebuild the tower shape.
+	height int
+	// span[level] is the number of level-0 hops from this node to tower[level]. It's what lets
+	// Rank/Select run in O(log n) instead of walking level 0. Meaningless when tower[level] is nil.
+	span []int
+	// prev is the level-0 backward pointer, i.e. a doubly-linked bottom level. It is nil for
+	// the first node in the list. This is what lets Iterator.Prev() run in O(1) instead of
+	// requiring a fresh search for every step backwards.
+	prev *node[K, V]
 }
 type SkipList[K any, V any] struct {
-	head          *node[K, V]
-	height        int
-	maxHeight     int
+	head        *node[K, V]
+	height      int
+	maxHeight   int
+	length      int
+	levelCounts []int
+	// tail[level] is the rightmost node occupying that level, or nil if the level is empty.
+	// It's what makes Max() O(1) instead of requiring a full descent from head.
+	tail          []*node[K, V]
 	probabilities []uint32
 	comparator    func(a, b K) int
+	randSource    func() uint32
 }
 
 func NewDefaultSkipList[K any, V any](keyComparator func(a, b K) int) *SkipList[K, V] {
@@ -26,6 +43,15 @@ func NewDefaultSkipList[K any, V any](keyComparator func(a, b K) int) *SkipList[
 // The key comparator function should return a negative value if a < b, 0 if a == b
 // and a positive value if a > b.
 func NewSkipList[K any, V any](keyComparator func(a, b K) int, performantCapacity int, pValue float64) *SkipList[K, V] {
+	return NewSkipListWithSource[K, V](keyComparator, performantCapacity, pValue, randv2.Uint32)
+}
+
+// NewSkipListWithSource is like NewSkipList but draws tower heights from source instead of the
+// package-global math/rand/v2 generator. This is useful both for reproducible benchmarks/fuzz
+// tests (two runs built from the same source produce identical towers) and for plugging in a
+// cheaper PRNG on insert-heavy workloads, since math/rand/v2's global generator pays for
+// thread-local/mutex-protected state that a per-list source doesn't need to.
+func NewSkipListWithSource[K any, V any](keyComparator func(a, b K) int, performantCapacity int, pValue float64, source func() uint32) *SkipList[K, V] {
 	if performantCapacity < 1 {
 		panic("performantCapacity must be at least 1")
 	}
@@ -35,11 +61,14 @@ func NewSkipList[K any, V any](keyComparator func(a, b K) int, performantCapacit
 	maxHeight := int(math.Ceil(logBaseX(1.0/pValue, float64(performantCapacity))))
 
 	answer := &SkipList[K, V]{
-		head:          &node[K, V]{tower: make([]*node[K, V], maxHeight)},
+		head:          &node[K, V]{tower: make([]*node[K, V], maxHeight), span: make([]int, maxHeight)},
 		height:        1,
 		maxHeight:     maxHeight,
+		levelCounts:   make([]int, maxHeight),
+		tail:          make([]*node[K, V], maxHeight),
 		probabilities: make([]uint32, maxHeight),
 		comparator:    keyComparator,
+		randSource:    source,
 	}
 
 	// Probablity of a node occupying level l (zero indexed) is pValue^l
@@ -56,12 +85,20 @@ func NewSkipList[K any, V any](keyComparator func(a, b K) int, performantCapacit
 	return answer
 }
 
+// NewSeededSkipList is like NewSkipList but seeds its tower-height generator deterministically,
+// so that two lists created with the same seed produce identical towers. This is mainly meant
+// for reproducing a benchmark run or a fuzz failure.
+func NewSeededSkipList[K any, V any](seed uint64, keyComparator func(a, b K) int, performantCapacity int, pValue float64) *SkipList[K, V] {
+	source := randv2.New(randv2.NewPCG(seed, seed))
+	return NewSkipListWithSource[K, V](keyComparator, performantCapacity, pValue, source.Uint32)
+}
+
 func logBaseX(base, x float64) float64 {
 	return math.Log(x) / math.Log(base)
 }
 
 func (s *SkipList[K, V]) randomHeight() int {
-	randVal := randv2.Uint32()
+	randVal := s.randSource()
 
 	height := 1
 	for height < s.maxHeight && randVal <= s.probabilities[height] {
@@ -71,10 +108,122 @@ func (s *SkipList[K, V]) randomHeight() int {
 	return height
 }
 
+// Height returns the current tallest occupied level, plus one (a list with only level-0 nodes
+// has Height() == 1).
+func (s *SkipList[K, V]) Height() int {
+	return s.height
+}
+
+// Len returns the number of key-value pairs currently in the list. It is maintained
+// incrementally by Insert, Delete and DeleteRange, so it runs in O(1) rather than a full scan.
+func (s *SkipList[K, V]) Len() int {
+	return s.length
+}
+
+// LevelStats reports how many nodes occupy a single level of the tower.
+type LevelStats struct {
+	Level int
+	Nodes int
+}
+
+// Stats returns the number of nodes occupying each level of the tower, from level 0 (every
+// node) up to the tallest currently-occupied level. It's meant for verifying that a chosen seed
+// (see NewSeededSkipList) produces a reasonably-shaped tower, not for hot-path use.
+func (s *SkipList[K, V]) Stats() []LevelStats {
+	stats := make([]LevelStats, s.height)
+	for level := 0; level < s.height; level++ {
+		stats[level] = LevelStats{Level: level, Nodes: s.levelCounts[level]}
+	}
+	return stats
+}
+
+// Empty reports whether the list holds no key-value pairs.
+func (s *SkipList[K, V]) Empty() bool {
+	return s.length == 0
+}
+
+// Clear removes every key-value pair from the list. It runs in O(maxHeight), resetting head's
+// tower and the length counter without reallocating probabilities.
+func (s *SkipList[K, V]) Clear() {
+	for level := range s.head.tower {
+		s.head.tower[level] = nil
+		s.head.span[level] = 0
+		s.tail[level] = nil
+		s.levelCounts[level] = 0
+	}
+	s.height = 1
+	s.length = 0
+}
+
+// Min returns the smallest key-value pair in the list. ok is false if the list is empty.
+func (s *SkipList[K, V]) Min() (K, V, bool) {
+	n := s.head.tower[0]
+	if n == nil {
+		return *new(K), *new(V), false
+	}
+	return n.key, n.value, true
+}
+
+// Max returns the largest key-value pair in the list. ok is false if the list is empty. This is
+// O(1) thanks to the per-level tail pointers maintained by Insert, Delete and DeleteRange.
+func (s *SkipList[K, V]) Max() (K, V, bool) {
+	n := s.tail[0]
+	if n == nil {
+		return *new(K), *new(V), false
+	}
+	return n.key, n.value, true
+}
+
+// Rank returns the 0-indexed position of key in ascending key order, and true if key is present.
+// It runs in O(log n) by summing the spans walked during the same descent search uses, rather
+// than counting nodes at level 0.
+func (s *SkipList[K, V]) Rank(key K) (int, bool) {
+	var next *node[K, V]
+	current := s.head
+	rank := 0
+	for level := s.height - 1; level >= 0; level-- {
+		for next = current.tower[level]; next != nil; next = current.tower[level] {
+			if s.comparator(key, next.key) <= 0 {
+				break
+			}
+			rank += current.span[level]
+			current = next
+		}
+	}
+
+	if next != nil && s.comparator(key, next.key) == 0 {
+		return rank, true
+	}
+	return 0, false
+}
+
+// Select returns the key-value pair at 0-indexed position i in ascending key order. ok is false
+// if i is out of range. Like Rank, it runs in O(log n) by following spans instead of walking
+// level 0.
+func (s *SkipList[K, V]) Select(i int) (K, V, bool) {
+	if i < 0 || i >= s.length {
+		return *new(K), *new(V), false
+	}
+
+	target := i + 1 // rank of head itself is 0
+	traversed := 0
+	current := s.head
+	for level := s.height - 1; level >= 0; level-- {
+		for current.tower[level] != nil && traversed+current.span[level] <= target {
+			traversed += current.span[level]
+			current = current.tower[level]
+		}
+		if traversed == target {
+			return current.key, current.value, true
+		}
+	}
+	return *new(K), *new(V), false
+}
+
 // Insert inserts a new key-value pair into the list. If the key already exists
 // the old value is returned along with true. If the key did not exist false is returned.
 func (s *SkipList[K, V]) Insert(key K, value V) (V, bool) {
-	found, rightmostSmaller := s.search(key)
+	found, rightmostSmaller, rank := s.searchWithRank(key)
 	if found != nil {
 		// The key already exists in the list. Update the value.
 		oldValue := found.value
@@ -86,9 +235,12 @@ func (s *SkipList[K, V]) Insert(key K, value V) (V, bool) {
 
 	if newNodeHeight > s.height {
 		// The new node is taller than the current list. This means that head will be the previous
-		// node for the new levels.
+		// node for the new levels. Its span is the full length of the list, exactly as if head's
+		// pointer at this level had always been skipping over every existing node.
 		for newLevel := s.height; newLevel < newNodeHeight; newLevel++ {
 			rightmostSmaller[newLevel] = s.head
+			rank[newLevel] = 0
+			s.head.span[newLevel] = s.length
 		}
 		s.height = newNodeHeight
 	}
@@ -96,13 +248,34 @@ func (s *SkipList[K, V]) Insert(key K, value V) (V, bool) {
 	// Insert a new node and point rightmostSmaller nodes at each level to the new node (up to
 	// the height of the new node).
 	newNode := &node[K, V]{
-		key:   key,
-		value: value,
-		tower: make([]*node[K, V], s.maxHeight),
+		key:    key,
+		value:  value,
+		tower:  make([]*node[K, V], s.maxHeight),
+		span:   make([]int, s.maxHeight),
+		height: newNodeHeight,
 	}
 	for level := 0; level < newNodeHeight; level++ {
 		newNode.tower[level] = rightmostSmaller[level].tower[level]
+		newNode.span[level] = rightmostSmaller[level].span[level] - (rank[0] - rank[level])
 		rightmostSmaller[level].tower[level] = newNode
+		rightmostSmaller[level].span[level] = rank[0] - rank[level] + 1
+		s.levelCounts[level]++
+		if newNode.tower[level] == nil {
+			s.tail[level] = newNode
+		}
+	}
+	// Every level the new node doesn't reach still gains one more level-0 node underneath it.
+	for level := newNodeHeight; level < s.height; level++ {
+		rightmostSmaller[level].span[level]++
+	}
+	s.length++
+
+	// Maintain the level-0 backward pointer on both sides of the new node.
+	if rightmostSmaller[0] != s.head {
+		newNode.prev = rightmostSmaller[0]
+	}
+	if newNode.tower[0] != nil {
+		newNode.tower[0].prev = newNode
 	}
 
 	return *new(V), false
@@ -116,15 +289,31 @@ func (s *SkipList[K, V]) Delete(key K) (V, bool) {
 		return *new(V), false
 	}
 
-	// Start from level 0 and see if the rightmost node with a smaller key at this level
-	// points directly to the node we're deleting. If it does, update the pointer to point
-	// to the next node. If it does not, we're done since it means we have reached the height
-	// of the node we're deleting.
+	// Unlike a plain pointer-only skip list, we can't stop at the first level where
+	// rightmostSmaller no longer points at found: every level up to s.height has a span that
+	// counts the node being removed, so every level needs updating, either by splicing found out
+	// (and folding its span into the predecessor's) or by simply shrinking the hop count by one.
 	for level := 0; level < s.height; level++ {
-		if rightmostSmaller[level].tower[level] != found {
-			break
+		if rightmostSmaller[level].tower[level] == found {
+			rightmostSmaller[level].tower[level] = found.tower[level]
+			rightmostSmaller[level].span[level] += found.span[level] - 1
+			if found.tower[level] == nil {
+				if rightmostSmaller[level] == s.head {
+					s.tail[level] = nil
+				} else {
+					s.tail[level] = rightmostSmaller[level]
+				}
+			}
+			s.levelCounts[level]--
+		} else {
+			rightmostSmaller[level].span[level]--
 		}
-		rightmostSmaller[level].tower[level] = found.tower[level]
+	}
+	s.length--
+
+	// Keep the level-0 backward pointer of the node that follows the deleted one in sync.
+	if found.tower[0] != nil {
+		found.tower[0].prev = found.prev
 	}
 
 	// Update the height of the list if the node we're deleting is the highest node in the list.
@@ -176,3 +365,255 @@ func (s *SkipList[K, V]) search(key K) (*node[K, V], []*node[K, V]) {
 	}
 	return nil, rightmostSmaller
 }
+
+// searchWithRank is like search but additionally returns, for each level, the level-0 rank (i.e.
+// number of hops from head) of rightmostSmaller at that level. Insert needs this to set the
+// new node's spans; Find, Delete, Seek, Range and DeleteRange only adjust spans relative to
+// their existing values and use the cheaper search instead.
+func (s *SkipList[K, V]) searchWithRank(key K) (*node[K, V], []*node[K, V], []int) {
+	var next *node[K, V]
+	rightmostSmaller := make([]*node[K, V], s.maxHeight)
+	rank := make([]int, s.maxHeight)
+
+	current := s.head
+	currentRank := 0
+	for level := s.height - 1; level >= 0; level-- {
+		for next = current.tower[level]; next != nil; next = current.tower[level] {
+			if s.comparator(key, next.key) <= 0 {
+				break
+			}
+			currentRank += current.span[level]
+			current = next
+		}
+		rank[level] = currentRank
+		rightmostSmaller[level] = current
+	}
+
+	if next != nil && s.comparator(key, next.key) == 0 {
+		return next, rightmostSmaller, rank
+	}
+	return nil, rightmostSmaller, rank
+}
+
+// Iterator is a cursor over a SkipList that can move forwards and backwards in key order.
+// A zero-value Iterator is not usable; obtain one from Seek, First or Last.
+type Iterator[K any, V any] struct {
+	list    *SkipList[K, V]
+	current *node[K, V]
+}
+
+// Seek positions a new Iterator at the smallest key that is greater than or equal to key.
+// If no such key exists the returned iterator is not Valid.
+func (s *SkipList[K, V]) Seek(key K) *Iterator[K, V] {
+	_, rightmostSmaller := s.search(key)
+	return &Iterator[K, V]{list: s, current: rightmostSmaller[0].tower[0]}
+}
+
+// First returns an Iterator positioned at the smallest key in the list. If the list is empty
+// the returned iterator is not Valid.
+func (s *SkipList[K, V]) First() *Iterator[K, V] {
+	return &Iterator[K, V]{list: s, current: s.head.tower[0]}
+}
+
+// Last returns an Iterator positioned at the largest key in the list. If the list is empty
+// the returned iterator is not Valid.
+func (s *SkipList[K, V]) Last() *Iterator[K, V] {
+	current := s.head
+	for level := s.height - 1; level >= 0; level-- {
+		for current.tower[level] != nil {
+			current = current.tower[level]
+		}
+	}
+	if current == s.head {
+		current = nil
+	}
+	return &Iterator[K, V]{list: s, current: current}
+}
+
+// Valid reports whether the iterator is positioned at a key-value pair.
+func (it *Iterator[K, V]) Valid() bool {
+	return it.current != nil
+}
+
+// Key returns the key at the iterator's current position. It panics if the iterator is not Valid.
+func (it *Iterator[K, V]) Key() K {
+	return it.current.key
+}
+
+// Value returns the value at the iterator's current position. It panics if the iterator is not Valid.
+func (it *Iterator[K, V]) Value() V {
+	return it.current.value
+}
+
+// Next advances the iterator to the next larger key. If there is no such key the iterator
+// becomes invalid.
+func (it *Iterator[K, V]) Next() {
+	if it.current != nil {
+		it.current = it.current.tower[0]
+	}
+}
+
+// Prev moves the iterator to the next smaller key. If there is no such key the iterator
+// becomes invalid.
+func (it *Iterator[K, V]) Prev() {
+	if it.current != nil {
+		it.current = it.current.prev
+	}
+}
+
+// Range calls fn for every key-value pair with a key in [lo, hi), in ascending order. Iteration
+// stops early if fn returns false.
+func (s *SkipList[K, V]) Range(lo, hi K, fn func(key K, value V) bool) {
+	for it := s.Seek(lo); it.Valid() && s.comparator(it.Key(), hi) < 0; it.Next() {
+		if !fn(it.Key(), it.Value()) {
+			return
+		}
+	}
+}
+
+// DeleteRange removes every key-value pair with a key in [lo, hi) in a single tower-walk and
+// returns the number of pairs removed. This is significantly cheaper than calling Delete once
+// per key, since the predecessor at each level only needs to be rewired once instead of once
+// per removed node.
+func (s *SkipList[K, V]) DeleteRange(lo, hi K) int {
+	_, rightmostSmaller := s.search(lo)
+
+	removed := 0
+	next := rightmostSmaller[0].tower[0]
+	for next != nil && s.comparator(next.key, hi) < 0 {
+		next = next.tower[0]
+		removed++
+	}
+	if removed == 0 {
+		return 0
+	}
+
+	// For every level, skip the rewired pointer past any node that falls inside the deleted
+	// range. rightmostSmaller[level] is untouched by the deletions above, so this is a single
+	// forward pass per level rather than one Delete per removed node. Spans telescope: the sum
+	// of every span along the way is the old hop count from rightmostSmaller[level] to the
+	// surviving node, and all removed nodes lie in between, so the new span is that sum minus
+	// removed.
+	for level := 0; level < s.height; level++ {
+		pred := rightmostSmaller[level]
+		span := pred.span[level]
+		cur := pred.tower[level]
+		levelRemoved := 0
+		for cur != nil && s.comparator(cur.key, hi) < 0 {
+			span += cur.span[level]
+			cur = cur.tower[level]
+			levelRemoved++
+		}
+		pred.tower[level] = cur
+		pred.span[level] = span - removed
+		s.levelCounts[level] -= levelRemoved
+		if cur == nil {
+			if pred == s.head {
+				s.tail[level] = nil
+			} else {
+				s.tail[level] = pred
+			}
+		}
+	}
+	s.length -= removed
+
+	if next != nil {
+		next.prev = rightmostSmaller[0]
+		if next.prev == s.head {
+			next.prev = nil
+		}
+	}
+
+	// Update the height of the list in case the deleted range emptied the top levels.
+	for s.height > 1 && s.head.tower[s.height-1] == nil {
+		s.height--
+	}
+
+	return removed
+}
+
+// Keys returns every key in the list in ascending order.
+func (s *SkipList[K, V]) Keys() []K {
+	keys := make([]K, 0, s.length)
+	for n := s.head.tower[0]; n != nil; n = n.tower[0] {
+		keys = append(keys, n.key)
+	}
+	return keys
+}
+
+// Values returns every value in the list, ordered by ascending key.
+func (s *SkipList[K, V]) Values() []V {
+	values := make([]V, 0, s.length)
+	for n := s.head.tower[0]; n != nil; n = n.tower[0] {
+		values = append(values, n.value)
+	}
+	return values
+}
+
+// Entries returns every key-value pair in the list, ordered by ascending key.
+func (s *SkipList[K, V]) Entries() []struct {
+	K K
+	V V
+} {
+	entries := make([]struct {
+		K K
+		V V
+	}, 0, s.length)
+	for n := s.head.tower[0]; n != nil; n = n.tower[0] {
+		entries = append(entries, struct {
+			K K
+			V V
+		}{K: n.key, V: n.value})
+	}
+	return entries
+}
+
+// Clone returns a deep copy of the list. It runs in O(n), rebuilding each node's tower in a
+// single forward pass over level 0 instead of re-Inserting every key (which would also reroll
+// random tower heights instead of reproducing the original shape).
+func (s *SkipList[K, V]) Clone() *SkipList[K, V] {
+	clone := &SkipList[K, V]{
+		head:          &node[K, V]{tower: make([]*node[K, V], s.maxHeight), span: make([]int, s.maxHeight)},
+		height:        s.height,
+		maxHeight:     s.maxHeight,
+		length:        s.length,
+		levelCounts:   append([]int(nil), s.levelCounts...),
+		tail:          make([]*node[K, V], s.maxHeight),
+		probabilities: s.probabilities,
+		comparator:    s.comparator,
+		randSource:    s.randSource,
+	}
+
+	cloneLastAtLevel := make([]*node[K, V], s.maxHeight)
+	origLastAtLevel := make([]*node[K, V], s.maxHeight)
+	for level := range cloneLastAtLevel {
+		cloneLastAtLevel[level] = clone.head
+		origLastAtLevel[level] = s.head
+	}
+
+	var prev *node[K, V]
+	for n := s.head.tower[0]; n != nil; n = n.tower[0] {
+		cloned := &node[K, V]{
+			key:    n.key,
+			value:  n.value,
+			tower:  make([]*node[K, V], s.maxHeight),
+			span:   make([]int, s.maxHeight),
+			height: n.height,
+			prev:   prev,
+		}
+		for level := 0; level < n.height; level++ {
+			cloneLastAtLevel[level].tower[level] = cloned
+			cloneLastAtLevel[level].span[level] = origLastAtLevel[level].span[level]
+			cloneLastAtLevel[level] = cloned
+			origLastAtLevel[level] = n
+		}
+		prev = cloned
+	}
+	for level := 0; level < s.maxHeight; level++ {
+		if cloneLastAtLevel[level] != clone.head {
+			clone.tail[level] = cloneLastAtLevel[level]
+		}
+	}
+
+	return clone
+}
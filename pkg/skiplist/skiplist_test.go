@@ -3,6 +3,7 @@ package skiplist
 import (
 	"bytes"
 	"cmp"
+	"fmt"
 	"math"
 	randv2 "math/rand/v2"
 	"testing"
@@ -10,8 +11,12 @@ import (
 	"github.com/kkdai/basiclist"
 )
 
+// MaxElements bounds how many of the precomputed random values the benchmarks below draw from,
+// so a single b.N run doesn't have to generate a fresh random value per iteration.
+const MaxElements = 1000
+
 func TestSkipList_Insert(t *testing.T) {
-	s := NewSkipList[[]byte, string](bytes.Compare)
+	s := NewSkipList[[]byte, string](bytes.Compare, 1024, 0.5)
 
 	key := []byte("key1")
 	value := "value1"
@@ -46,7 +51,7 @@ func TestSkipList_Insert(t *testing.T) {
 }
 
 func TestSkipList_Delete(t *testing.T) {
-	s := NewSkipList[string, []byte](cmp.Compare[string])
+	s := NewSkipList[string, []byte](cmp.Compare[string], 1024, 0.5)
 
 	key := "key1"
 	value := []byte("value1")
@@ -87,7 +92,7 @@ func TestSkipList_Delete(t *testing.T) {
 }
 
 func TestSkipList_Find(t *testing.T) {
-	s := NewSkipList[int, []byte](cmp.Compare[int])
+	s := NewSkipList[int, []byte](cmp.Compare[int], 1024, 0.5)
 
 	key := 1
 	value := []byte("value1")
@@ -112,7 +117,7 @@ func TestSkipList_Find(t *testing.T) {
 	}
 }
 func TestSkipList_InsertAndRemoveRandomElements(t *testing.T) {
-	s := NewSkipList[int, int](cmp.Compare[int])
+	s := NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
 	rndVals := randomIntValues(100)
 
 	// Insert 100 random elements
@@ -145,6 +150,378 @@ func TestSkipList_InsertAndRemoveRandomElements(t *testing.T) {
 	}
 }
 
+func TestSkipList_Iterator(t *testing.T) {
+	s := NewSkipList[int, string](cmp.Compare[int], 1024, 0.5)
+	for _, key := range []int{5, 1, 3, 4, 2} {
+		s.Insert(key, fmt.Sprintf("value%d", key))
+	}
+
+	// Forward iteration from First() should visit keys in ascending order.
+	var forward []int
+	for it := s.First(); it.Valid(); it.Next() {
+		forward = append(forward, it.Key())
+	}
+	expectIntSlice(t, forward, []int{1, 2, 3, 4, 5})
+
+	// Backward iteration from Last() should visit keys in descending order.
+	var backward []int
+	for it := s.Last(); it.Valid(); it.Prev() {
+		backward = append(backward, it.Key())
+	}
+	expectIntSlice(t, backward, []int{5, 4, 3, 2, 1})
+
+	// Seek should land on the smallest key >= the given key.
+	it := s.Seek(3)
+	if !it.Valid() || it.Key() != 3 {
+		t.Fatalf("expected Seek(3) to land on key 3, got valid=%v", it.Valid())
+	}
+	if it.Value() != "value3" {
+		t.Errorf("expected value3, got %v", it.Value())
+	}
+
+	it = s.Seek(6)
+	if it.Valid() {
+		t.Errorf("expected Seek(6) to be invalid, got key %v", it.Key())
+	}
+
+	// An empty list should produce invalid iterators from First, Last and Seek.
+	empty := NewSkipList[int, string](cmp.Compare[int], 1024, 0.5)
+	if empty.First().Valid() || empty.Last().Valid() || empty.Seek(0).Valid() {
+		t.Errorf("expected iterators over an empty list to be invalid")
+	}
+}
+
+func TestSkipList_Range(t *testing.T) {
+	s := NewSkipList[int, string](cmp.Compare[int], 1024, 0.5)
+	for _, key := range []int{5, 1, 3, 4, 2} {
+		s.Insert(key, fmt.Sprintf("value%d", key))
+	}
+
+	var seen []int
+	s.Range(2, 5, func(key int, value string) bool {
+		seen = append(seen, key)
+		return true
+	})
+	expectIntSlice(t, seen, []int{2, 3, 4})
+
+	// Returning false from fn should stop the scan early.
+	seen = nil
+	s.Range(1, 6, func(key int, value string) bool {
+		seen = append(seen, key)
+		return key < 3
+	})
+	expectIntSlice(t, seen, []int{1, 2, 3})
+}
+
+func TestSkipList_DeleteRange(t *testing.T) {
+	s := NewSkipList[int, string](cmp.Compare[int], 1024, 0.5)
+	for _, key := range []int{1, 2, 3, 4, 5, 6} {
+		s.Insert(key, fmt.Sprintf("value%d", key))
+	}
+
+	removed := s.DeleteRange(2, 5)
+	if removed != 3 {
+		t.Errorf("expected 3 keys removed, got %v", removed)
+	}
+
+	var remaining []int
+	for it := s.First(); it.Valid(); it.Next() {
+		remaining = append(remaining, it.Key())
+	}
+	expectIntSlice(t, remaining, []int{1, 5, 6})
+
+	// The list should still be walkable backwards after the range delete.
+	var reverse []int
+	for it := s.Last(); it.Valid(); it.Prev() {
+		reverse = append(reverse, it.Key())
+	}
+	expectIntSlice(t, reverse, []int{6, 5, 1})
+
+	// Deleting a range with no matching keys should be a no-op.
+	if removed := s.DeleteRange(100, 200); removed != 0 {
+		t.Errorf("expected 0 keys removed, got %v", removed)
+	}
+}
+
+func TestSkipList_LenAndHeightAndStats(t *testing.T) {
+	s := NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
+
+	if s.Len() != 0 {
+		t.Errorf("expected Len() to be 0 for an empty list, got %v", s.Len())
+	}
+	if s.Height() != 1 {
+		t.Errorf("expected Height() to be 1 for an empty list, got %v", s.Height())
+	}
+
+	for i := 0; i < 100; i++ {
+		s.Insert(i, i)
+	}
+	if s.Len() != 100 {
+		t.Errorf("expected Len() to be 100, got %v", s.Len())
+	}
+
+	stats := s.Stats()
+	if len(stats) != s.Height() {
+		t.Fatalf("expected Stats() to have one entry per level up to Height(), got %v entries for height %v", len(stats), s.Height())
+	}
+	if stats[0].Nodes != 100 {
+		t.Errorf("expected level 0 to hold all 100 nodes, got %v", stats[0].Nodes)
+	}
+	for level := 1; level < len(stats); level++ {
+		if stats[level].Nodes > stats[level-1].Nodes {
+			t.Errorf("expected level %v to hold no more nodes than level %v, got %v > %v", level, level-1, stats[level].Nodes, stats[level-1].Nodes)
+		}
+	}
+
+	for i := 0; i < 100; i += 2 {
+		s.Delete(i)
+	}
+	if s.Len() != 50 {
+		t.Errorf("expected Len() to be 50 after deleting half the keys, got %v", s.Len())
+	}
+
+	s.DeleteRange(0, 100)
+	if s.Len() != 0 {
+		t.Errorf("expected Len() to be 0 after DeleteRange covering everything, got %v", s.Len())
+	}
+	for _, levelStat := range s.Stats() {
+		if levelStat.Nodes != 0 {
+			t.Errorf("expected every level to be empty after DeleteRange, got %v nodes at level %v", levelStat.Nodes, levelStat.Level)
+		}
+	}
+}
+
+func TestSkipList_SeededSkipListIsDeterministic(t *testing.T) {
+	build := func() *SkipList[int, int] {
+		s := NewSeededSkipList[int, int](42, cmp.Compare[int], 65536, 0.5)
+		for i := 0; i < 200; i++ {
+			s.Insert(i, i)
+		}
+		return s
+	}
+
+	a := build()
+	b := build()
+
+	if a.Height() != b.Height() {
+		t.Fatalf("expected two lists built from the same seed to have the same height, got %v and %v", a.Height(), b.Height())
+	}
+	statsA, statsB := a.Stats(), b.Stats()
+	for level := range statsA {
+		if statsA[level].Nodes != statsB[level].Nodes {
+			t.Errorf("expected level %v to have the same node count for both lists, got %v and %v", level, statsA[level].Nodes, statsB[level].Nodes)
+		}
+	}
+}
+
+func TestSkipList_WithSourceUsesInjectedRandomness(t *testing.T) {
+	// A source that always returns 0 means every coin flip in randomHeight "succeeds", so the
+	// very first node inserted should reach the maximum height.
+	always0 := func() uint32 { return 0 }
+	s := NewSkipListWithSource[int, int](cmp.Compare[int], 8, 0.5, always0)
+
+	s.Insert(1, 1)
+
+	maxHeight := len(s.probabilities)
+	if s.Height() != maxHeight {
+		t.Fatalf("expected a constant-0 source to produce a max-height node, got height %v for maxHeight %v", s.Height(), maxHeight)
+	}
+	if top := s.Stats()[maxHeight-1].Nodes; top != 1 {
+		t.Errorf("expected exactly 1 node at the top level, got %v", top)
+	}
+}
+
+func TestSkipList_EmptyClearKeysValuesEntries(t *testing.T) {
+	s := NewSkipList[int, string](cmp.Compare[int], 1024, 0.5)
+
+	if !s.Empty() {
+		t.Errorf("expected a fresh list to be Empty()")
+	}
+
+	for i := 0; i < 10; i++ {
+		s.Insert(i, fmt.Sprintf("v%d", i))
+	}
+	if s.Empty() {
+		t.Errorf("expected a populated list to not be Empty()")
+	}
+
+	expectIntSlice(t, s.Keys(), []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	values := s.Values()
+	if len(values) != 10 {
+		t.Fatalf("expected 10 values, got %v", len(values))
+	}
+	for i, v := range values {
+		if v != fmt.Sprintf("v%d", i) {
+			t.Errorf("expected Values()[%d] to be v%d, got %v", i, i, v)
+		}
+	}
+
+	entries := s.Entries()
+	if len(entries) != 10 {
+		t.Fatalf("expected 10 entries, got %v", len(entries))
+	}
+	for i, e := range entries {
+		if e.K != i || e.V != fmt.Sprintf("v%d", i) {
+			t.Errorf("expected Entries()[%d] to be {%d v%d}, got %+v", i, i, i, e)
+		}
+	}
+
+	s.Clear()
+	if !s.Empty() || s.Len() != 0 || s.Height() != 1 {
+		t.Errorf("expected Clear() to empty the list, got Empty()=%v Len()=%v Height()=%v", s.Empty(), s.Len(), s.Height())
+	}
+	if len(s.Keys()) != 0 {
+		t.Errorf("expected no keys after Clear(), got %v", s.Keys())
+	}
+	if _, found := s.Find(0); found {
+		t.Errorf("expected key 0 to be gone after Clear()")
+	}
+
+	// The list must still behave normally after Clear(), i.e. probabilities weren't clobbered.
+	s.Insert(42, "answer")
+	if v, found := s.Find(42); !found || v != "answer" {
+		t.Errorf("expected to find (42, answer) after reusing a cleared list, got (%v, %v)", v, found)
+	}
+}
+
+func TestSkipList_MinMax(t *testing.T) {
+	s := NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
+
+	if _, _, ok := s.Min(); ok {
+		t.Errorf("expected Min() on an empty list to report ok=false")
+	}
+	if _, _, ok := s.Max(); ok {
+		t.Errorf("expected Max() on an empty list to report ok=false")
+	}
+
+	for _, k := range []int{5, 1, 9, 3, 7} {
+		s.Insert(k, k*k)
+	}
+
+	if k, v, ok := s.Min(); !ok || k != 1 || v != 1 {
+		t.Errorf("expected Min() to be (1, 1), got (%v, %v, %v)", k, v, ok)
+	}
+	if k, v, ok := s.Max(); !ok || k != 9 || v != 81 {
+		t.Errorf("expected Max() to be (9, 81), got (%v, %v, %v)", k, v, ok)
+	}
+
+	s.Delete(9)
+	if k, _, ok := s.Max(); !ok || k != 7 {
+		t.Errorf("expected Max() to fall back to 7 after deleting the old max, got (%v, %v)", k, ok)
+	}
+
+	s.DeleteRange(0, 100)
+	if _, _, ok := s.Max(); ok {
+		t.Errorf("expected Max() to report ok=false after DeleteRange emptied the list")
+	}
+}
+
+func TestSkipList_RankAndSelect(t *testing.T) {
+	s := NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
+
+	if _, ok := s.Rank(0); ok {
+		t.Errorf("expected Rank() on an empty list to report ok=false")
+	}
+	if _, _, ok := s.Select(0); ok {
+		t.Errorf("expected Select() on an empty list to report ok=false")
+	}
+
+	for i := 0; i < 100; i++ {
+		s.Insert(i, i*10)
+	}
+
+	for i := 0; i < 100; i++ {
+		if rank, ok := s.Rank(i); !ok || rank != i {
+			t.Fatalf("expected Rank(%d) to be %d, got (%v, %v)", i, i, rank, ok)
+		}
+		if k, v, ok := s.Select(i); !ok || k != i || v != i*10 {
+			t.Fatalf("expected Select(%d) to be (%d, %d), got (%v, %v, %v)", i, i, i*10, k, v, ok)
+		}
+	}
+
+	if _, ok := s.Rank(100); ok {
+		t.Errorf("expected Rank() of a missing key to report ok=false")
+	}
+	if _, _, ok := s.Select(100); ok {
+		t.Errorf("expected Select() past the end to report ok=false")
+	}
+	if _, _, ok := s.Select(-1); ok {
+		t.Errorf("expected Select() of a negative index to report ok=false")
+	}
+
+	for i := 0; i < 100; i += 3 {
+		s.Delete(i)
+	}
+	for i, key := range s.Keys() {
+		if rank, ok := s.Rank(key); !ok || rank != i {
+			t.Errorf("expected Rank(%d) to be %d after deletions, got (%v, %v)", key, i, rank, ok)
+		}
+		if k, _, ok := s.Select(i); !ok || k != key {
+			t.Errorf("expected Select(%d) to be %d after deletions, got (%v, %v)", i, key, k, ok)
+		}
+	}
+}
+
+func TestSkipList_Clone(t *testing.T) {
+	s := NewSeededSkipList[int, int](7, cmp.Compare[int], 65536, 0.5)
+	for i := 0; i < 200; i++ {
+		s.Insert(i, i*i)
+	}
+	s.Delete(50)
+
+	clone := s.Clone()
+
+	// The clone must reproduce the exact same tower shape as the original, not a freshly rolled
+	// one, since it's built from the original's node heights rather than by re-Inserting keys.
+	if clone.Height() != s.Height() {
+		t.Fatalf("expected clone to have the same Height(), got %v want %v", clone.Height(), s.Height())
+	}
+	origStats, cloneStats := s.Stats(), clone.Stats()
+	for level := range origStats {
+		if origStats[level].Nodes != cloneStats[level].Nodes {
+			t.Errorf("expected clone level %v to have %v nodes, got %v", level, origStats[level].Nodes, cloneStats[level].Nodes)
+		}
+	}
+
+	expectIntSlice(t, clone.Keys(), s.Keys())
+	if clone.Len() != s.Len() {
+		t.Errorf("expected clone Len() to be %v, got %v", s.Len(), clone.Len())
+	}
+	if k, v, ok := clone.Max(); !ok || k != 199 || v != 199*199 {
+		t.Errorf("expected clone Max() to be (199, %v), got (%v, %v, %v)", 199*199, k, v, ok)
+	}
+	for i := 0; i < clone.Len(); i++ {
+		origKey, _, _ := s.Select(i)
+		cloneKey, _, ok := clone.Select(i)
+		if !ok || cloneKey != origKey {
+			t.Errorf("expected clone Select(%d) to be %v, got (%v, %v)", i, origKey, cloneKey, ok)
+		}
+	}
+
+	// Mutating the clone must not affect the original, and vice versa.
+	clone.Insert(1000, -1)
+	if _, found := s.Find(1000); found {
+		t.Errorf("expected inserting into the clone to not affect the original")
+	}
+	s.Insert(2000, -1)
+	if _, found := clone.Find(2000); found {
+		t.Errorf("expected inserting into the original to not affect the clone")
+	}
+}
+
+func expectIntSlice(t *testing.T, got, want []int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
 func BenchmarkSkipList_Insert(b *testing.B) {
 	rndVals := randomIntValuesB(b)
 	var s *SkipList[int, int]
@@ -155,7 +532,7 @@ func BenchmarkSkipList_Insert(b *testing.B) {
 		if rndValIx == 0 {
 			// Starting from the beginning of the random values list, create a new skip list
 			// to avoid just inserting keys that are already in the list.
-			s = NewSkipList[int, int](cmp.Compare[int])
+			s = NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
 		}
 		b.StartTimer()
 		s.Insert(rndVals[rndValIx], i)
@@ -172,7 +549,7 @@ func BenchmarkSkipList_Delete(b *testing.B) {
 		if rndValIx == 0 {
 			// Starting from the beginning of the random values list, create a new skip list
 			// and populate it with random values so were not deleting from an empty list.
-			s = NewSkipList[int, int](cmp.Compare[int])
+			s = NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
 			for i := 0; i < len(rndVals); i++ {
 				s.Insert(rndVals[i], i)
 			}
@@ -183,7 +560,7 @@ func BenchmarkSkipList_Delete(b *testing.B) {
 }
 
 func BenchmarkSkipList_Find(b *testing.B) {
-	s := NewSkipList[int, int](cmp.Compare[int])
+	s := NewSkipList[int, int](cmp.Compare[int], 1024, 0.5)
 	rndVals := randomIntValuesB(b)
 
 	for i := 0; i < len(rndVals); i++ {